@@ -5,53 +5,126 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/bytedance/sonic"
+	"github.com/carlossantin/mcp-agents-go/api"
 	"github.com/carlossantin/mcp-agents-go/server"
 	"github.com/life4/genesis/slices"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/tmc/langchaingo/llms"
 )
 
+// localToolPrefix namespaces local tool names in the same way MCP server
+// names namespace their tools, so ExecuteTool can tell the two apart.
+const localToolPrefix = "local__"
+
 type MCPAgent struct {
-	Name           string                `json:"name"`
-	MCPServerNames []MCPAgentServer      `json:"mcp_servers"` // List of MCP servers used by this agent
-	MCPServerTools map[string][]mcp.Tool `json:"-"`           // Map of MCP server names to their allowed tools
-	mcpServers     []*server.MCPServer   `json:"-"`
-	Provider       string                `json:"provider"` // Name of the LLM provider to use
-	LLMModel       llms.Model            `json:"-"`
+	Name           string                       `json:"name"`
+	MCPServerNames []MCPAgentServer             `json:"mcp_servers"` // List of MCP servers used by this agent
+	MCPServerTools map[string][]mcp.Tool        `json:"-"`           // Map of MCP server names to their allowed tools
+	mcpServers     map[string]server.ToolCaller `json:"-"`           // MCP server name -> live caller (*server.MCPServer or *server.Supervisor)
+	LocalTools     []api.ToolSpec               `json:"-"`           // Natively implemented tools, merged in alongside MCP tools
+	Provider       string                       `json:"provider"`    // Name of the LLM provider to use
+	LLMModel       llms.Model                   `json:"-"`
+
+	usageMu    sync.Mutex
+	totalUsage Usage
+}
+
+// Usage tracks token accounting for a single generation call or, via
+// TotalUsage, accumulated over the agent's lifetime.
+type Usage struct {
+	PromptTokens     int
+	CompletionTokens int
+	ToolTokens       int
+	TotalTokens      int
+	Calls            int
 }
 
-func NewMCPAgent(ctx context.Context, name string, mcpServersForAgent []MCPAgentServer, servers []server.MCPServer, provider string, llmModel llms.Model) *MCPAgent {
+// Add accumulates other into u in place.
+func (u *Usage) Add(other Usage) {
+	u.PromptTokens += other.PromptTokens
+	u.CompletionTokens += other.CompletionTokens
+	u.ToolTokens += other.ToolTokens
+	u.TotalTokens += other.TotalTokens
+	u.Calls += other.Calls
+}
+
+// usageFromResponse extracts token accounting from the GenerationInfo map
+// langchaingo attaches to the first choice of a ContentResponse.
+func usageFromResponse(resp *llms.ContentResponse) Usage {
+	usage := Usage{Calls: 1}
+	if resp == nil || len(resp.Choices) == 0 || resp.Choices[0].GenerationInfo == nil {
+		return usage
+	}
+
+	info := resp.Choices[0].GenerationInfo
+	if v, ok := info["PromptTokens"].(int); ok {
+		usage.PromptTokens = v
+	}
+	if v, ok := info["CompletionTokens"].(int); ok {
+		usage.CompletionTokens = v
+	}
+	if v, ok := info["TotalTokens"].(int); ok {
+		usage.TotalTokens = v
+	}
+
+	return usage
+}
+
+// addUsage folds usage into the agent's cumulative TotalUsage.
+func (m *MCPAgent) addUsage(usage Usage) {
+	m.usageMu.Lock()
+	defer m.usageMu.Unlock()
+	m.totalUsage.Add(usage)
+}
+
+// TotalUsage returns the token usage accumulated across every GenerateContent,
+// Run, and RunStreaming call made by this agent so far.
+func (m *MCPAgent) TotalUsage() Usage {
+	m.usageMu.Lock()
+	defer m.usageMu.Unlock()
+	return m.totalUsage
+}
+
+// NewMCPAgent builds an agent over mcpServersForAgent, resolving each entry's
+// Name against callers (keyed by server name). callers holds a
+// *server.Supervisor for any server configured with supervision and a plain
+// *server.MCPServer otherwise, so calls made through the resulting agent keep
+// going through the Supervisor's round-robin/reconnect logic rather than a
+// one-time snapshot of it.
+func NewMCPAgent(ctx context.Context, name string, mcpServersForAgent []MCPAgentServer, callers map[string]server.ToolCaller, localTools []api.ToolSpec, provider string, llmModel llms.Model) *MCPAgent {
 	ag := &MCPAgent{
 		Name:           name,
 		MCPServerNames: mcpServersForAgent,
 		MCPServerTools: make(map[string][]mcp.Tool),
-		mcpServers:     []*server.MCPServer{},
+		mcpServers:     map[string]server.ToolCaller{},
+		LocalTools:     localTools,
 		Provider:       provider,
 		LLMModel:       llmModel,
 	}
 
 	for _, srv := range mcpServersForAgent {
-		mcpServer, err := slices.Find(servers, func(s server.MCPServer) bool {
-			return s.Name == srv.Name
-		})
-		if err == nil {
-			ag.mcpServers = append(ag.mcpServers, &mcpServer)
-			toolsRes, err := mcpServer.Client.ListTools(ctx, mcp.ListToolsRequest{})
-			if err == nil && toolsRes != nil {
-				allowedTools := slices.Filter(toolsRes.Tools, func(tool mcp.Tool) bool {
-					return len(srv.AllowedTools) == 0 || slices.Contains(srv.AllowedTools, tool.Name)
-				})
-				if _, ok := ag.MCPServerTools[srv.Name]; !ok {
-					ag.MCPServerTools[srv.Name] = []mcp.Tool{}
-				}
-				ag.MCPServerTools[srv.Name] = append(ag.MCPServerTools[srv.Name], allowedTools...)
-				allowedToolNames := slices.Map(allowedTools, func(tool mcp.Tool) string {
-					return tool.Name
-				})
-				fmt.Printf("Agent %s is allowed to use tools: %s on server %s\n", ag.Name, strings.Join(allowedToolNames, ", "), srv.Name)
+		caller, ok := callers[srv.Name]
+		if !ok {
+			continue
+		}
+
+		ag.mcpServers[srv.Name] = caller
+		toolsRes, err := caller.ListTools(ctx, mcp.ListToolsRequest{})
+		if err == nil && toolsRes != nil {
+			allowedTools := slices.Filter(toolsRes.Tools, func(tool mcp.Tool) bool {
+				return len(srv.AllowedTools) == 0 || slices.Contains(srv.AllowedTools, tool.Name)
+			})
+			if _, ok := ag.MCPServerTools[srv.Name]; !ok {
+				ag.MCPServerTools[srv.Name] = []mcp.Tool{}
 			}
+			ag.MCPServerTools[srv.Name] = append(ag.MCPServerTools[srv.Name], allowedTools...)
+			allowedToolNames := slices.Map(allowedTools, func(tool mcp.Tool) string {
+				return tool.Name
+			})
+			fmt.Printf("Agent %s is allowed to use tools: %s on server %s\n", ag.Name, strings.Join(allowedToolNames, ", "), srv.Name)
 		}
 	}
 
@@ -65,6 +138,10 @@ type MCPAgentServer struct {
 
 // InvokableRun executes the tool by mapping back to the original name and server
 func (m *MCPAgent) ExecuteTool(ctx context.Context, toolName, argumentsInJSON string) (string, error) {
+	if strings.HasPrefix(toolName, localToolPrefix) {
+		return m.executeLocalTool(ctx, strings.TrimPrefix(toolName, localToolPrefix), argumentsInJSON)
+	}
+
 	// Handle empty or invalid JSON arguments
 	var arguments any
 	if argumentsInJSON == "" || argumentsInJSON == "{}" {
@@ -80,14 +157,12 @@ func (m *MCPAgent) ExecuteTool(ctx context.Context, toolName, argumentsInJSON st
 
 	serverName := toolName[:strings.Index(toolName, "__")]
 	toolName = toolName[strings.Index(toolName, "__")+2:] // Remove the server prefix
-	toolServer, err := slices.Find(m.mcpServers, func(srv *server.MCPServer) bool {
-		return srv.Name == serverName
-	})
-	if err != nil {
-		return "", err
+	toolServer, ok := m.mcpServers[serverName]
+	if !ok {
+		return "", fmt.Errorf("mcp server %s not found for agent %s", serverName, m.Name)
 	}
 
-	result, err := toolServer.Client.CallTool(ctx, mcp.CallToolRequest{
+	result, err := toolServer.CallTool(ctx, mcp.CallToolRequest{
 		Request: mcp.Request{
 			Method: "tools/call",
 		},
@@ -116,133 +191,136 @@ func (m *MCPAgent) ExecuteTool(ctx context.Context, toolName, argumentsInJSON st
 	return marshaledResult, nil
 }
 
-func (m *MCPAgent) GenerateContentAsStreaming(ctx context.Context, prompt string, addNotFinalResponses bool) chan string {
-	msgs := []llms.MessageContent{
-		{Role: "human", Parts: []llms.ContentPart{llms.TextContent{Text: prompt}}},
+// executeLocalTool dispatches to the Impl of a LocalTools entry matching
+// toolName, decoding argumentsInJSON into the map[string]any it expects.
+func (m *MCPAgent) executeLocalTool(ctx context.Context, toolName, argumentsInJSON string) (string, error) {
+	spec, err := slices.Find(m.LocalTools, func(t api.ToolSpec) bool {
+		return t.Name == toolName
+	})
+	if err != nil {
+		return "", fmt.Errorf("local tool %s not found", toolName)
 	}
 
-	tools := m.ExtractToolsFromAgent()
-	streamingChan := make(chan string)
-
-	go func() {
-		defer close(streamingChan)
-
-		resp, err := m.LLMModel.GenerateContent(ctx, msgs, llms.WithTools(tools), llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
-			// Check if the chunk contains tool call information
-			var chunkData map[string]interface{}
-			// First check if chunk is a tool call array
-			var toolCallArray []interface{}
-			if err := json.Unmarshal(chunk, &toolCallArray); err == nil && len(toolCallArray) > 0 {
-				// This is a tool call array, don't send to channel
-				return nil
-			}
-
-			// If not a tool call array, check if it's a regular response with tool calls
-			if err := json.Unmarshal(chunk, &chunkData); err == nil {
-				// If it's a tool call, don't send to channel
-				if choices, ok := chunkData["choices"].([]interface{}); ok && len(choices) > 0 {
-					if choice, ok := choices[0].(map[string]interface{}); ok {
-						if toolCalls, ok := choice["tool_calls"].([]interface{}); ok && len(toolCalls) > 0 {
-							return nil
-						}
-					}
-				}
-			}
-			streamingChan <- string(chunk)
-			return nil
-		}))
-		if err != nil {
-			streamingChan <- fmt.Sprintf("Error generating content: %v", err)
-			return
+	arguments := map[string]any{}
+	if argumentsInJSON != "" && argumentsInJSON != "{}" {
+		if err := json.Unmarshal([]byte(argumentsInJSON), &arguments); err != nil {
+			return "", fmt.Errorf("invalid JSON arguments: %w", err)
 		}
+	}
 
-		// Handle tool calls after streaming is complete
-		if len(resp.Choices) > 0 && len(resp.Choices[0].ToolCalls) > 0 {
-			for _, suggestedTool := range resp.Choices[0].ToolCalls {
-				if addNotFinalResponses {
-					streamingChan <- fmt.Sprintf("\n[tool_usage] %s\n", suggestedTool.FunctionCall.Name)
-				}
+	result, err := spec.Impl(ctx, arguments)
+	if err != nil {
+		return "", fmt.Errorf("failed to call local tool: %w", err)
+	}
 
-				toolRes, err := m.ExecuteTool(ctx, suggestedTool.FunctionCall.Name, suggestedTool.FunctionCall.Arguments)
-				if err != nil {
-					streamingChan <- fmt.Sprintf("Error executing tool: %v", err)
-					return
-				}
+	return result, nil
+}
 
-				msgs = append(msgs, llms.MessageContent{
-					Role: "ai",
-					Parts: []llms.ContentPart{
-						suggestedTool,
-					},
-				})
+// GenerateContentAsStreaming runs the same generate/execute loop as
+// RunStreaming and emits the same typed Events, so callers can tell
+// assistant text apart from tool-call activity instead of demultiplexing a
+// chan string by re-parsing provider-specific JSON chunks. See RunStreaming's
+// doc comment for the caveat on tool-call detection not being streaming.
+func (m *MCPAgent) GenerateContentAsStreaming(ctx context.Context, prompt string, addNotFinalResponses bool) <-chan Event {
+	return m.RunStreaming(ctx, prompt, RunOptions{AddNotFinalResponses: addNotFinalResponses})
+}
 
-				msgs = append(msgs, llms.MessageContent{
-					Role: "tool",
-					Parts: []llms.ContentPart{
-						llms.ToolCallResponse{
-							ToolCallID: suggestedTool.ID,
-							Content:    toolRes,
-						},
-					},
-				})
+// GenerateContent runs the same generate/execute loop as Run, auto-approving
+// every tool call, so callers that don't need approval gating get identical
+// multi-hop tool-call behavior to Run and RunStreaming instead of a
+// hand-rolled, single-hop copy of the loop.
+func (m *MCPAgent) GenerateContent(ctx context.Context, prompt string, addNotFinalResponses bool) (string, Usage, error) {
+	return m.Run(ctx, prompt, RunOptions{AddNotFinalResponses: addNotFinalResponses})
+}
 
-				if addNotFinalResponses {
-					msgToPrint := toolRes
-					if len(msgToPrint) > 1000 {
-						msgToPrint = msgToPrint[:1000] + "..."
-					}
-					streamingChan <- fmt.Sprintf("\n[tool_response] %s: %s]\n\n", suggestedTool.FunctionCall.Name, msgToPrint)
-				}
-			}
+// RunOptions configures a Run or RunStreaming call.
+type RunOptions struct {
+	AddNotFinalResponses bool
+	// Approver decides what happens to each tool call the LLM proposes. A
+	// nil Approver auto-approves every call, matching the behavior of
+	// GenerateContent and GenerateContentAsStreaming.
+	Approver api.ToolApprover
+}
 
-			// Generate final response with tool results
-			finalResp, err := m.LLMModel.GenerateContent(ctx, msgs, llms.WithTools(tools), llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
-				streamingChan <- string(chunk)
-				return nil
-			}))
-			if err != nil {
-				streamingChan <- fmt.Sprintf("Error generating final content: %v", err)
-				return
-			}
-			_ = finalResp
-		}
-	}()
+// autoApprover approves every proposed ToolCall without inspection.
+type autoApprover struct{}
 
-	return streamingChan
+func (autoApprover) Approve(ctx context.Context, call api.ToolCall) (api.ApprovalDecision, error) {
+	return api.ApprovalDecision{Outcome: api.Allow}, nil
 }
 
-func (m *MCPAgent) GenerateContent(ctx context.Context, prompt string, addNotFinalResponses bool) string {
+// Run drives the generate/approve/execute loop: it asks the LLM for a
+// response, surfaces any proposed tool calls to opts.Approver, executes the
+// calls it allows, and feeds the results back until the LLM stops requesting
+// tools. GenerateContent is a thin wrapper around Run with an auto-approver,
+// so both share this one loop instead of drifting as near-identical copies.
+func (m *MCPAgent) Run(ctx context.Context, prompt string, opts RunOptions) (string, Usage, error) {
+	approver := opts.Approver
+	if approver == nil {
+		approver = autoApprover{}
+	}
+
 	msgs := []llms.MessageContent{
 		{Role: "human", Parts: []llms.ContentPart{llms.TextContent{Text: prompt}}},
 	}
-
 	tools := m.ExtractToolsFromAgent()
-	resp, err := m.LLMModel.GenerateContent(ctx, msgs, llms.WithTools(tools))
-	if err != nil {
-		panic(err)
-	}
+	response := ""
+	usage := Usage{}
 
-	toolCalls := resp.Choices[0].ToolCalls
+	for {
+		resp, err := m.LLMModel.GenerateContent(ctx, msgs, llms.WithTools(tools))
+		if err != nil {
+			m.addUsage(usage)
+			return "", usage, fmt.Errorf("failed to generate content: %w", err)
+		}
 
-	response := ""
+		toolCalls := resp.Choices[0].ToolCalls
+		roundUsage := usageFromResponse(resp)
+		if len(toolCalls) > 0 {
+			// This round's tokens went toward proposing a tool call rather
+			// than a final answer, so they count as tool round-trip usage
+			// in addition to the running total.
+			roundUsage.ToolTokens = roundUsage.TotalTokens
+		}
+		usage.Add(roundUsage)
+
+		if len(toolCalls) == 0 {
+			response += resp.Choices[0].Content
+			break
+		}
 
-	if len(toolCalls) > 0 {
 		for _, suggestedTool := range toolCalls {
-			if addNotFinalResponses {
-				response += fmt.Sprintf("\n[tool_usage] %s\n", suggestedTool.FunctionCall.Name)
+			call := api.ToolCall{
+				ID:        suggestedTool.ID,
+				Name:      suggestedTool.FunctionCall.Name,
+				Arguments: suggestedTool.FunctionCall.Arguments,
 			}
-			toolRes, err := m.ExecuteTool(ctx, suggestedTool.FunctionCall.Name, suggestedTool.FunctionCall.Arguments)
+
+			decision, err := approver.Approve(ctx, call)
 			if err != nil {
-				panic(err)
+				m.addUsage(usage)
+				return "", usage, fmt.Errorf("failed to approve tool call %s: %w", call.Name, err)
 			}
 
 			msgs = append(msgs, llms.MessageContent{
-				Role: "ai",
-				Parts: []llms.ContentPart{
-					suggestedTool,
-				},
+				Role:  "ai",
+				Parts: []llms.ContentPart{suggestedTool},
 			})
 
+			toolRes, err := m.resolveToolCall(ctx, call, decision)
+			if err != nil {
+				m.addUsage(usage)
+				return "", usage, err
+			}
+
+			if opts.AddNotFinalResponses {
+				msgToPrint := toolRes
+				if len(msgToPrint) > 1000 {
+					msgToPrint = msgToPrint[:1000] + "..."
+				}
+				response += fmt.Sprintf("\n[tool_usage] %s\n\n[tool_response] %s: %s]\n\n", call.Name, call.Name, msgToPrint)
+			}
+
 			msgs = append(msgs, llms.MessageContent{
 				Role: "tool",
 				Parts: []llms.ContentPart{
@@ -252,27 +330,140 @@ func (m *MCPAgent) GenerateContent(ctx context.Context, prompt string, addNotFin
 					},
 				},
 			})
-
-			if addNotFinalResponses {
-				msgToPrint := toolRes
-				if len(msgToPrint) > 1000 {
-					msgToPrint = msgToPrint[:1000] + "..."
-				}
-				response += fmt.Sprintf("\n[tool_response] %s: %s]\n\n", suggestedTool.FunctionCall.Name, msgToPrint)
-			}
 		}
+	}
 
-		resp, err := m.LLMModel.GenerateContent(ctx, msgs, llms.WithTools(tools))
+	m.addUsage(usage)
+	return response, usage, nil
+}
+
+// resolveToolCall applies an ApprovalDecision to a proposed ToolCall,
+// returning the content to feed back to the LLM as the tool result.
+func (m *MCPAgent) resolveToolCall(ctx context.Context, call api.ToolCall, decision api.ApprovalDecision) (string, error) {
+	switch decision.Outcome {
+	case api.Deny:
+		reason := decision.Reason
+		if reason == "" {
+			reason = "tool call denied by approver"
+		}
+		return reason, nil
+	case api.Modify:
+		toolRes, err := m.ExecuteTool(ctx, call.Name, decision.Arguments)
 		if err != nil {
-			panic(err)
+			return "", fmt.Errorf("failed to execute tool %s: %w", call.Name, err)
 		}
+		return toolRes, nil
+	default:
+		toolRes, err := m.ExecuteTool(ctx, call.Name, call.Arguments)
+		if err != nil {
+			return "", fmt.Errorf("failed to execute tool %s: %w", call.Name, err)
+		}
+		return toolRes, nil
+	}
+}
 
-		response += resp.Choices[0].Content
-	} else {
-		response += resp.Choices[0].Content
+// RunStreaming is the streaming counterpart of Run: it emits Event values on
+// the returned channel instead of returning a single string, so a caller can
+// render assistant text and tool-call activity separately.
+//
+// Tool-call detection is still not streaming: WithStreamingFunc only
+// forwards chunks of the assistant's text content as TextDelta, and
+// resp.Choices[0].ToolCalls is only populated, and only inspected, once the
+// full round finishes. If a backend's streaming callback ever interleaves a
+// raw tool-call JSON fragment into the same byte stream as the text content,
+// that fragment would be forwarded as TextDelta with nothing to filter it
+// out; this hasn't been observed against the langchaingo/Azure OpenAI
+// backend this repo targets, but hasn't been ruled out either.
+func (m *MCPAgent) RunStreaming(ctx context.Context, prompt string, opts RunOptions) <-chan Event {
+	approver := opts.Approver
+	if approver == nil {
+		approver = autoApprover{}
 	}
 
-	return response
+	events := make(chan Event)
+
+	go func() {
+		defer close(events)
+
+		msgs := []llms.MessageContent{
+			{Role: "human", Parts: []llms.ContentPart{llms.TextContent{Text: prompt}}},
+		}
+		tools := m.ExtractToolsFromAgent()
+		finalText := ""
+
+		for {
+			resp, err := m.LLMModel.GenerateContent(ctx, msgs, llms.WithTools(tools), llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+				events <- TextDelta{Text: string(chunk)}
+				return nil
+			}))
+			if err != nil {
+				events <- Error{Err: fmt.Errorf("failed to generate content: %w", err)}
+				events <- Done{FinalText: finalText}
+				return
+			}
+
+			toolCalls := resp.Choices[0].ToolCalls
+			usage := usageFromResponse(resp)
+			if len(toolCalls) > 0 {
+				// This round's tokens went toward proposing a tool call
+				// rather than a final answer, so they count as tool
+				// round-trip usage in addition to the running total.
+				usage.ToolTokens = usage.TotalTokens
+			}
+			m.addUsage(usage)
+			events <- UsageReport{Usage: usage}
+
+			if len(toolCalls) == 0 {
+				finalText += resp.Choices[0].Content
+				break
+			}
+
+			for _, suggestedTool := range toolCalls {
+				call := api.ToolCall{
+					ID:        suggestedTool.ID,
+					Name:      suggestedTool.FunctionCall.Name,
+					Arguments: suggestedTool.FunctionCall.Arguments,
+				}
+				events <- ToolCallProposed{Call: call}
+
+				decision, err := approver.Approve(ctx, call)
+				if err != nil {
+					approveErr := fmt.Errorf("failed to approve tool call %s: %w", call.Name, err)
+					events <- ToolCallResult{Call: call, Err: approveErr}
+					events <- Error{Err: approveErr}
+					events <- Done{FinalText: finalText}
+					return
+				}
+
+				msgs = append(msgs, llms.MessageContent{
+					Role:  "ai",
+					Parts: []llms.ContentPart{suggestedTool},
+				})
+
+				toolRes, err := m.resolveToolCall(ctx, call, decision)
+				events <- ToolCallResult{Call: call, Content: toolRes, Err: err}
+				if err != nil {
+					events <- Error{Err: err}
+					events <- Done{FinalText: finalText}
+					return
+				}
+
+				msgs = append(msgs, llms.MessageContent{
+					Role: "tool",
+					Parts: []llms.ContentPart{
+						llms.ToolCallResponse{
+							ToolCallID: suggestedTool.ID,
+							Content:    toolRes,
+						},
+					},
+				})
+			}
+		}
+
+		events <- Done{FinalText: finalText}
+	}()
+
+	return events
 }
 
 func (m *MCPAgent) ExtractToolsFromAgent() []llms.Tool {
@@ -297,5 +488,16 @@ func (m *MCPAgent) ExtractToolsFromAgent() []llms.Tool {
 		}
 	}
 
+	for _, localTool := range m.LocalTools {
+		result = append(result, llms.Tool{
+			Type: "function",
+			Function: &llms.FunctionDefinition{
+				Name:        localToolPrefix + localTool.Name,
+				Description: localTool.Description,
+				Parameters:  localTool.Parameters,
+			},
+		})
+	}
+
 	return result
 }
@@ -0,0 +1,48 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+func TestUsageAdd(t *testing.T) {
+	u := Usage{PromptTokens: 1, CompletionTokens: 2, ToolTokens: 3, TotalTokens: 6, Calls: 1}
+	u.Add(Usage{PromptTokens: 10, CompletionTokens: 20, ToolTokens: 30, TotalTokens: 60, Calls: 1})
+
+	want := Usage{PromptTokens: 11, CompletionTokens: 22, ToolTokens: 33, TotalTokens: 66, Calls: 2}
+	if u != want {
+		t.Fatalf("Add() = %+v, want %+v", u, want)
+	}
+}
+
+func TestUsageFromResponse(t *testing.T) {
+	resp := &llms.ContentResponse{
+		Choices: []*llms.ContentChoice{
+			{
+				GenerationInfo: map[string]any{
+					"PromptTokens":     5,
+					"CompletionTokens": 7,
+					"TotalTokens":      12,
+				},
+			},
+		},
+	}
+
+	got := usageFromResponse(resp)
+	want := Usage{PromptTokens: 5, CompletionTokens: 7, TotalTokens: 12, Calls: 1}
+	if got != want {
+		t.Fatalf("usageFromResponse() = %+v, want %+v", got, want)
+	}
+}
+
+func TestUsageFromResponseNilOrEmpty(t *testing.T) {
+	if got := usageFromResponse(nil); got != (Usage{Calls: 1}) {
+		t.Fatalf("usageFromResponse(nil) = %+v, want {Calls: 1}", got)
+	}
+
+	empty := &llms.ContentResponse{Choices: []*llms.ContentChoice{{}}}
+	if got := usageFromResponse(empty); got != (Usage{Calls: 1}) {
+		t.Fatalf("usageFromResponse(empty) = %+v, want {Calls: 1}", got)
+	}
+}
@@ -0,0 +1,77 @@
+package agent
+
+import "github.com/carlossantin/mcp-agents-go/api"
+
+// Event is emitted on the channel returned by RunStreaming and
+// GenerateContentAsStreaming, letting callers distinguish assistant text
+// deltas from tool-call lifecycle notifications instead of parsing ad-hoc
+// banner strings out of a chan string.
+type Event interface {
+	isEvent()
+}
+
+// TextDelta carries a chunk of assistant text as it streams in.
+type TextDelta struct{ Text string }
+
+// ToolCallProposed is emitted as soon as the LLM proposes a tool call, before
+// the approver has decided what to do with it.
+type ToolCallProposed struct{ Call api.ToolCall }
+
+// ToolCallResult is emitted once a proposed tool call has been resolved,
+// whether it was executed, denied, or failed.
+type ToolCallResult struct {
+	Call    api.ToolCall
+	Content string
+	Err     error
+}
+
+// UsageReport is emitted once per LLM round, carrying the token usage for
+// that round alone (not the running total — see MCPAgent.TotalUsage).
+type UsageReport struct{ Usage Usage }
+
+// Error is emitted when the LLM round trip itself, approving a tool call, or
+// executing a tool call fails. A Done event carrying whatever text was
+// collected so far always follows.
+type Error struct{ Err error }
+
+// Done is emitted once after the loop finishes, carrying the assistant text
+// of the final round (the one with no tool calls). Text the LLM returns
+// alongside tool calls in earlier rounds is not included.
+type Done struct{ FinalText string }
+
+func (TextDelta) isEvent()        {}
+func (ToolCallProposed) isEvent() {}
+func (ToolCallResult) isEvent()   {}
+func (UsageReport) isEvent()      {}
+func (Error) isEvent()            {}
+func (Done) isEvent()             {}
+
+// Collect drains an Event channel for callers that just want the final
+// text, accumulated usage, and first error — so main.go stays
+// one-liner-simple instead of switching on every Event variant.
+func Collect(events <-chan Event) (string, Usage, error) {
+	var (
+		finalText string
+		usage     Usage
+		firstErr  error
+	)
+
+	for event := range events {
+		switch e := event.(type) {
+		case Done:
+			finalText = e.FinalText
+		case UsageReport:
+			usage.Add(e.Usage)
+		case Error:
+			if firstErr == nil {
+				firstErr = e.Err
+			}
+		case ToolCallResult:
+			if firstErr == nil && e.Err != nil {
+				firstErr = e.Err
+			}
+		}
+	}
+
+	return finalText, usage, firstErr
+}
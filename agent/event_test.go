@@ -0,0 +1,58 @@
+package agent
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCollect(t *testing.T) {
+	events := make(chan Event, 8)
+	events <- TextDelta{Text: "hel"}
+	events <- TextDelta{Text: "lo"}
+	events <- UsageReport{Usage: Usage{TotalTokens: 5, Calls: 1}}
+	events <- ToolCallResult{Content: "ok"}
+	events <- UsageReport{Usage: Usage{TotalTokens: 7, Calls: 1}}
+	events <- Done{FinalText: "hello"}
+	close(events)
+
+	finalText, usage, err := Collect(events)
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if finalText != "hello" {
+		t.Fatalf("Collect() finalText = %q, want %q", finalText, "hello")
+	}
+	if want := (Usage{TotalTokens: 12, Calls: 2}); usage != want {
+		t.Fatalf("Collect() usage = %+v, want %+v", usage, want)
+	}
+}
+
+func TestCollectFirstError(t *testing.T) {
+	errA := errors.New("first")
+	errB := errors.New("second")
+
+	events := make(chan Event, 4)
+	events <- Error{Err: errA}
+	events <- Error{Err: errB}
+	events <- Done{FinalText: ""}
+	close(events)
+
+	_, _, err := Collect(events)
+	if !errors.Is(err, errA) {
+		t.Fatalf("Collect() error = %v, want the first error (%v)", err, errA)
+	}
+}
+
+func TestCollectToolCallResultError(t *testing.T) {
+	toolErr := errors.New("tool failed")
+
+	events := make(chan Event, 4)
+	events <- ToolCallResult{Err: toolErr}
+	events <- Done{FinalText: ""}
+	close(events)
+
+	_, _, err := Collect(events)
+	if !errors.Is(err, toolErr) {
+		t.Fatalf("Collect() error = %v, want %v", err, toolErr)
+	}
+}
@@ -0,0 +1,86 @@
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/carlossantin/mcp-agents-go/api"
+	"github.com/carlossantin/mcp-agents-go/server"
+)
+
+// newTestAgentWithLocalTool builds an MCPAgent whose only capability is a
+// single local tool, so resolveToolCall can be exercised without standing up
+// an MCP server or LLM.
+func newTestAgentWithLocalTool(t *testing.T, impl func(ctx context.Context, args map[string]any) (string, error)) *MCPAgent {
+	t.Helper()
+	return &MCPAgent{
+		Name: "test-agent",
+		LocalTools: []api.ToolSpec{
+			{Name: "echo", Impl: impl},
+		},
+		mcpServers: map[string]server.ToolCaller{},
+	}
+}
+
+func TestResolveToolCallAllow(t *testing.T) {
+	ag := newTestAgentWithLocalTool(t, func(ctx context.Context, args map[string]any) (string, error) {
+		return "echoed: " + args["msg"].(string), nil
+	})
+
+	call := api.ToolCall{Name: localToolPrefix + "echo", Arguments: `{"msg":"hi"}`}
+	got, err := ag.resolveToolCall(context.Background(), call, api.ApprovalDecision{Outcome: api.Allow})
+	if err != nil {
+		t.Fatalf("resolveToolCall() error = %v", err)
+	}
+	if want := "echoed: hi"; got != want {
+		t.Fatalf("resolveToolCall() = %q, want %q", got, want)
+	}
+}
+
+func TestResolveToolCallDeny(t *testing.T) {
+	ag := newTestAgentWithLocalTool(t, func(ctx context.Context, args map[string]any) (string, error) {
+		t.Fatal("Impl should not be called when the decision is Deny")
+		return "", nil
+	})
+
+	call := api.ToolCall{Name: localToolPrefix + "echo", Arguments: `{"msg":"hi"}`}
+	got, err := ag.resolveToolCall(context.Background(), call, api.ApprovalDecision{Outcome: api.Deny, Reason: "not allowed"})
+	if err != nil {
+		t.Fatalf("resolveToolCall() error = %v", err)
+	}
+	if want := "not allowed"; got != want {
+		t.Fatalf("resolveToolCall() = %q, want %q", got, want)
+	}
+}
+
+func TestResolveToolCallDenyDefaultReason(t *testing.T) {
+	ag := newTestAgentWithLocalTool(t, func(ctx context.Context, args map[string]any) (string, error) {
+		t.Fatal("Impl should not be called when the decision is Deny")
+		return "", nil
+	})
+
+	call := api.ToolCall{Name: localToolPrefix + "echo"}
+	got, err := ag.resolveToolCall(context.Background(), call, api.ApprovalDecision{Outcome: api.Deny})
+	if err != nil {
+		t.Fatalf("resolveToolCall() error = %v", err)
+	}
+	if want := "tool call denied by approver"; got != want {
+		t.Fatalf("resolveToolCall() = %q, want %q", got, want)
+	}
+}
+
+func TestResolveToolCallModify(t *testing.T) {
+	ag := newTestAgentWithLocalTool(t, func(ctx context.Context, args map[string]any) (string, error) {
+		return "echoed: " + args["msg"].(string), nil
+	})
+
+	call := api.ToolCall{Name: localToolPrefix + "echo", Arguments: `{"msg":"original"}`}
+	decision := api.ApprovalDecision{Outcome: api.Modify, Arguments: `{"msg":"modified"}`}
+	got, err := ag.resolveToolCall(context.Background(), call, decision)
+	if err != nil {
+		t.Fatalf("resolveToolCall() error = %v", err)
+	}
+	if want := "echoed: modified"; got != want {
+		t.Fatalf("resolveToolCall() = %q, want %q", got, want)
+	}
+}
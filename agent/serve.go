@@ -0,0 +1,69 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/carlossantin/mcp-agents-go/api"
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+)
+
+// Serve registers this agent as an MCP server advertising a single "ask"
+// tool (plus one tool per LocalTools entry), so another MCP-aware host -
+// Claude Desktop, or another instance of this module acting as a "router"
+// agent - can mount it the same way it mounts any other MCP server.
+func (m *MCPAgent) Serve(ctx context.Context, transportType string) error {
+	srv := mcpserver.NewMCPServer(m.Name, "1.0.0")
+
+	askTool := mcp.NewTool("ask",
+		mcp.WithDescription(fmt.Sprintf("Ask the %s agent a question.", m.Name)),
+		mcp.WithString("prompt", mcp.Required(), mcp.Description("The prompt to send to the agent.")),
+		mcp.WithString("context", mcp.Description("Optional extra context to prepend to the prompt.")),
+	)
+	srv.AddTool(askTool, m.handleAsk)
+
+	for _, localTool := range m.LocalTools {
+		srv.AddTool(mcp.NewTool(localTool.Name, mcp.WithDescription(localTool.Description)), handleLocalTool(localTool))
+	}
+
+	switch transportType {
+	case "stdio":
+		return mcpserver.ServeStdio(srv)
+	case "sse":
+		return mcpserver.NewSSEServer(srv).Start(":8080")
+	case "http":
+		return mcpserver.NewStreamableHTTPServer(srv).Start(":8080")
+	default:
+		return fmt.Errorf("unsupported transport type %q for agent %s", transportType, m.Name)
+	}
+}
+
+// handleAsk implements the "ask" tool: it runs GenerateContent with the
+// given prompt (prefixed with context, if any) and returns the final text.
+func (m *MCPAgent) handleAsk(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	prompt, _ := args["prompt"].(string)
+	if extraContext, ok := args["context"].(string); ok && extraContext != "" {
+		prompt = extraContext + "\n\n" + prompt
+	}
+
+	response, _, err := m.GenerateContent(ctx, prompt, false)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+// handleLocalTool adapts a LocalTools entry's Impl to the mcp-go server
+// ToolHandlerFunc signature.
+func handleLocalTool(spec api.ToolSpec) mcpserver.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := spec.Impl(ctx, req.GetArguments())
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText(result), nil
+	}
+}
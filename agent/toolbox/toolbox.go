@@ -0,0 +1,233 @@
+// Package toolbox provides native (non-MCP) ToolSpec implementations that an
+// MCPAgent can be configured with directly, without spinning up a separate
+// MCP server process.
+package toolbox
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/carlossantin/mcp-agents-go/api"
+)
+
+// Builtins maps a tool name (as used in config.MCPAgent's local_tools list)
+// to its api.ToolSpec.
+var Builtins = map[string]api.ToolSpec{
+	"dir_tree":  dirTreeTool,
+	"read_file": readFileTool,
+	"http_get":  httpGetTool,
+}
+
+// Lookup returns the built-in ToolSpec registered under name, if any.
+func Lookup(name string) (api.ToolSpec, bool) {
+	spec, ok := Builtins[name]
+	return spec, ok
+}
+
+// readFileRoot restricts read_file to paths inside this directory, so an
+// agent configured with the read_file local tool can't be steered into
+// returning arbitrary files on disk (e.g. "../../etc/passwd"). Defaults to
+// the current directory; set via SetReadFileRoot.
+var readFileRoot = "."
+
+// SetReadFileRoot restricts read_file to paths inside root. Pass "" to
+// reset to the default (the current directory).
+func SetReadFileRoot(root string) {
+	if root == "" {
+		root = "."
+	}
+	readFileRoot = root
+}
+
+// resolveWithinRoot joins root and path (if path isn't already absolute)
+// and rejects the result if it would escape root, via ".." segments or an
+// absolute path naming somewhere else entirely.
+func resolveWithinRoot(root, path string) (string, error) {
+	rootAbs, err := filepath.Abs(root)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve root %s: %w", root, err)
+	}
+
+	joined := path
+	if !filepath.IsAbs(path) {
+		joined = filepath.Join(rootAbs, path)
+	}
+	resolved, err := filepath.Abs(joined)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+
+	rel, err := filepath.Rel(rootAbs, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %s escapes the allowed root %s", path, rootAbs)
+	}
+
+	return resolved, nil
+}
+
+var dirTreeTool = api.ToolSpec{
+	Name:        "dir_tree",
+	Description: "Lists files and directories under a path, recursively.",
+	Parameters: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"path": map[string]interface{}{
+				"type":        "string",
+				"description": "Root directory to list. Defaults to the current directory.",
+			},
+		},
+	},
+	Impl: func(ctx context.Context, args map[string]any) (string, error) {
+		root, _ := args["path"].(string)
+		if root == "" {
+			root = "."
+		}
+
+		var sb strings.Builder
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(root, path)
+			if err != nil {
+				rel = path
+			}
+			if rel == "." {
+				return nil
+			}
+			sb.WriteString(rel)
+			if info.IsDir() {
+				sb.WriteString("/")
+			}
+			sb.WriteString("\n")
+			return nil
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to walk %s: %w", root, err)
+		}
+
+		return sb.String(), nil
+	},
+}
+
+var readFileTool = api.ToolSpec{
+	Name:        "read_file",
+	Description: "Reads the contents of a file and returns it as text.",
+	Parameters: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"path": map[string]interface{}{
+				"type":        "string",
+				"description": "Path of the file to read.",
+			},
+		},
+	},
+	Impl: func(ctx context.Context, args map[string]any) (string, error) {
+		path, _ := args["path"].(string)
+		if path == "" {
+			return "", fmt.Errorf("read_file requires a path argument")
+		}
+
+		resolved, err := resolveWithinRoot(readFileRoot, path)
+		if err != nil {
+			return "", err
+		}
+
+		content, err := os.ReadFile(resolved)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		return string(content), nil
+	},
+}
+
+var httpGetTool = api.ToolSpec{
+	Name:        "http_get",
+	Description: "Performs an HTTP GET request and returns the response body as text.",
+	Parameters: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"url": map[string]interface{}{
+				"type":        "string",
+				"description": "URL to fetch.",
+			},
+		},
+	},
+	Impl: func(ctx context.Context, args map[string]any) (string, error) {
+		rawURL, _ := args["url"].(string)
+		if rawURL == "" {
+			return "", fmt.Errorf("http_get requires a url argument")
+		}
+
+		if err := checkURLAllowed(ctx, rawURL); err != nil {
+			return "", err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to build request for %s: %w", rawURL, err)
+		}
+
+		// Re-check every redirect target, so an allowed host can't bounce the
+		// request on to a disallowed address.
+		client := &http.Client{
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= 10 {
+					return fmt.Errorf("stopped after 10 redirects")
+				}
+				return checkURLAllowed(req.Context(), req.URL.String())
+			},
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch %s: %w", rawURL, err)
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return "", fmt.Errorf("failed to read response from %s: %w", rawURL, err)
+		}
+
+		return string(body), nil
+	},
+}
+
+// checkURLAllowed rejects non-http(s) schemes and any host that resolves to
+// a loopback, private, link-local, or unspecified address, so http_get can't
+// be used to reach internal services or cloud metadata endpoints (e.g.
+// 169.254.169.254) from behind whatever network this process runs on.
+func checkURLAllowed(ctx context.Context, rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid url %s: %w", rawURL, err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("unsupported url scheme %q", parsed.Scheme)
+	}
+
+	host := parsed.Hostname()
+	if host == "" {
+		return fmt.Errorf("url %s has no host", rawURL)
+	}
+
+	ips, err := net.DefaultResolver.LookupIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("failed to resolve host %s: %w", host, err)
+	}
+	for _, ip := range ips {
+		if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+			return fmt.Errorf("url %s resolves to disallowed address %s", rawURL, ip)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package toolbox
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDirTreeTool(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatalf("Mkdir() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sub", "file.txt"), []byte("hi"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	got, err := dirTreeTool.Impl(context.Background(), map[string]any{"path": dir})
+	if err != nil {
+		t.Fatalf("Impl() error = %v", err)
+	}
+	if !strings.Contains(got, "sub/") || !strings.Contains(got, filepath.Join("sub", "file.txt")) {
+		t.Fatalf("Impl() = %q, want entries for sub/ and sub/file.txt", got)
+	}
+}
+
+func TestReadFileTool(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "allowed.txt"), []byte("contents"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	SetReadFileRoot(dir)
+	defer SetReadFileRoot("")
+
+	got, err := readFileTool.Impl(context.Background(), map[string]any{"path": "allowed.txt"})
+	if err != nil {
+		t.Fatalf("Impl() error = %v", err)
+	}
+	if got != "contents" {
+		t.Fatalf("Impl() = %q, want %q", got, "contents")
+	}
+}
+
+func TestReadFileToolRejectsEscape(t *testing.T) {
+	dir := t.TempDir()
+	SetReadFileRoot(dir)
+	defer SetReadFileRoot("")
+
+	_, err := readFileTool.Impl(context.Background(), map[string]any{"path": "../../etc/passwd"})
+	if err == nil {
+		t.Fatal("Impl() error = nil, want an error for a path escaping the root")
+	}
+}
+
+func TestCheckURLAllowedRejectsBadScheme(t *testing.T) {
+	if err := checkURLAllowed(context.Background(), "ftp://example.com"); err == nil {
+		t.Fatal("checkURLAllowed() error = nil, want an error for a non-http(s) scheme")
+	}
+}
+
+func TestCheckURLAllowedRejectsLoopback(t *testing.T) {
+	if err := checkURLAllowed(context.Background(), "http://127.0.0.1/secret"); err == nil {
+		t.Fatal("checkURLAllowed() error = nil, want an error for a loopback address")
+	}
+}
+
+func TestCheckURLAllowedRejectsLinkLocal(t *testing.T) {
+	if err := checkURLAllowed(context.Background(), "http://169.254.169.254/latest/meta-data/"); err == nil {
+		t.Fatal("checkURLAllowed() error = nil, want an error for a link-local address")
+	}
+}
@@ -0,0 +1,41 @@
+package api
+
+import "context"
+
+// ToolCall represents a tool invocation proposed by the LLM. It is surfaced
+// to a ToolApprover before it is executed, so a caller can inspect, rewrite,
+// or reject it instead of the agent silently calling it.
+type ToolCall struct {
+	ID        string
+	Name      string
+	Arguments string
+}
+
+// ApprovalOutcome is the decision a ToolApprover makes about a proposed
+// ToolCall.
+type ApprovalOutcome int
+
+const (
+	// Allow executes the ToolCall unchanged.
+	Allow ApprovalOutcome = iota
+	// Deny skips execution; Reason is returned to the LLM as the tool result.
+	Deny
+	// Modify executes the ToolCall with Arguments replaced.
+	Modify
+)
+
+// ApprovalDecision is returned by a ToolApprover for a single ToolCall.
+type ApprovalDecision struct {
+	Outcome ApprovalOutcome
+	// Arguments replaces the proposed call's arguments when Outcome is Modify.
+	Arguments string
+	// Reason is surfaced back to the LLM as the tool result when Outcome is Deny.
+	Reason string
+}
+
+// ToolApprover decides whether a proposed ToolCall should be executed,
+// denied, or executed with modified arguments. Implementations can prompt a
+// user for confirmation, consult a policy, or auto-approve.
+type ToolApprover interface {
+	Approve(ctx context.Context, call ToolCall) (ApprovalDecision, error)
+}
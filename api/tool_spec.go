@@ -0,0 +1,14 @@
+package api
+
+import "context"
+
+// ToolSpec describes a tool implemented natively in this process, as
+// opposed to one proxied to an MCP server. Agents can be configured with a
+// list of ToolSpecs so they gain capabilities without needing a separate
+// MCP server process.
+type ToolSpec struct {
+	Name        string
+	Description string
+	Parameters  map[string]interface{}
+	Impl        func(ctx context.Context, args map[string]any) (string, error)
+}
@@ -3,8 +3,12 @@ package config
 import (
 	"context"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/carlossantin/mcp-agents-go/agent"
+	"github.com/carlossantin/mcp-agents-go/agent/toolbox"
+	"github.com/carlossantin/mcp-agents-go/api"
 	"github.com/carlossantin/mcp-agents-go/server"
 	"github.com/gookit/config/v2"
 	"github.com/gookit/config/v2/yaml"
@@ -14,15 +18,30 @@ import (
 )
 
 var SysConfig = &SystemConfig{
-	LLMModels: map[string]llms.Model{},
-	Servers:   map[string]server.MCPServer{},
-	Agents:    map[string]agent.MCPAgent{},
+	LLMModels:   map[string]llms.Model{},
+	Servers:     map[string]server.MCPServer{},
+	Supervisors: map[string]*server.Supervisor{},
+	ToolCallers: map[string]server.ToolCaller{},
+	Agents:      map[string]*agent.MCPAgent{},
 }
 
 type SystemConfig struct {
 	LLMModels map[string]llms.Model
 	Servers   map[string]server.MCPServer
-	Agents    map[string]agent.MCPAgent
+	// Supervisors holds the supervised connection for any server configured
+	// with retry/backoff/healthcheck knobs, keyed by server name.
+	Supervisors map[string]*server.Supervisor
+	// ToolCallers holds, for every configured server, the live object agents
+	// should call through: the *server.Supervisor if it's supervised, a plain
+	// *server.MCPServer otherwise. Agents are built from this map (not from
+	// Servers) so a Supervisor's reconnects and round-robin stay reachable
+	// instead of being frozen at agent-construction time.
+	ToolCallers map[string]server.ToolCaller
+	// Agents holds a pointer per agent, not a value: MCPAgent carries a
+	// sync.Mutex guarding its usage accounting, and a value copy pulled out
+	// of the map would accumulate usage on a throwaway instance that's
+	// discarded when the caller returns.
+	Agents map[string]*agent.MCPAgent
 }
 
 type LLMProvider struct {
@@ -36,16 +55,95 @@ type LLMProvider struct {
 
 type MCPServer struct {
 	Name    string   `mapstructure:"name"`
-	Type    string   `mapstructure:"type"`    // e.g., stdio, sse
+	Type    string   `mapstructure:"type"`    // e.g., stdio, sse, streamable-http
 	Command []string `mapstructure:"command"` // Command to start the server (stdio)
-	URL     string   `mapstructure:"url"`     // URL for the server connection (sse)
-	Headers []string `mapstructure:"headers"` // Headers for the server connection (sse)
+	URL     string   `mapstructure:"url"`     // URL for the server connection (sse/streamable-http)
+	Headers []string `mapstructure:"headers"` // Headers for the server connection (sse/streamable-http)
+	Auth    Auth     `mapstructure:"auth"`    // Authentication for the sse/streamable-http type
+
+	// RetryLimit, Backoff, MaxProcs, and HealthcheckInterval are optional;
+	// setting any of them supervises the connection with server.Supervisor
+	// instead of connecting once via server.NewMCPServer. Zero values fall
+	// back to server.DefaultSupervisorConfig.
+	RetryLimit          int     `mapstructure:"retry_limit"`
+	Backoff             Backoff `mapstructure:"backoff"`
+	MaxProcs            int     `mapstructure:"max_procs"`
+	HealthcheckInterval string  `mapstructure:"healthcheck_interval"` // e.g. "30s"
+}
+
+// Backoff configures server.Supervisor's reconnect delay. Start and Cap are
+// duration strings, e.g. "1s" / "30s".
+type Backoff struct {
+	Start string `mapstructure:"start"`
+	Cap   string `mapstructure:"cap"`
+}
+
+// Auth configures authentication for an sse/streamable-http MCPServer. Type
+// selects which of the remaining fields apply: "bearer" (BearerTokenEnv),
+// "oauth2_client_credentials" (TokenURL/ClientID/ClientSecret/Scopes), or
+// empty for none. Headers are always added regardless of Type.
+type Auth struct {
+	Type           string            `mapstructure:"type"`
+	BearerTokenEnv string            `mapstructure:"bearer_token_env"` // Env var holding the bearer token
+	Headers        map[string]string `mapstructure:"headers"`
+	TokenURL       string            `mapstructure:"token_url"`
+	ClientID       string            `mapstructure:"client_id"`
+	ClientSecret   string            `mapstructure:"client_secret"`
+	Scopes         []string          `mapstructure:"scopes"`
+	LeewaySeconds  int               `mapstructure:"leeway_seconds"` // Refresh this many seconds before expiry, default 30
+}
+
+// serverAuth resolves a to a server.AuthConfig, reading BearerTokenEnv from
+// the environment.
+func (a Auth) serverAuth() server.AuthConfig {
+	cfg := server.AuthConfig{
+		Type:          server.AuthType(a.Type),
+		BearerToken:   os.Getenv(a.BearerTokenEnv),
+		StaticHeaders: a.Headers,
+		TokenURL:      a.TokenURL,
+		ClientID:      a.ClientID,
+		ClientSecret:  a.ClientSecret,
+		Scopes:        a.Scopes,
+	}
+	if a.LeewaySeconds > 0 {
+		cfg.Leeway = time.Duration(a.LeewaySeconds) * time.Second
+	}
+	return cfg
+}
+
+// supervised reports whether sv configures any Supervisor knob, in which
+// case initMCPServers connects it via server.NewSupervisor.
+func (sv MCPServer) supervised() bool {
+	return sv.RetryLimit != 0 || sv.MaxProcs != 0 || sv.HealthcheckInterval != "" || sv.Backoff.Start != "" || sv.Backoff.Cap != ""
+}
+
+// supervisorConfig builds a server.SupervisorConfig from sv's YAML knobs,
+// leaving unset fields at their zero value so server.NewSupervisor applies
+// server.DefaultSupervisorConfig's defaults.
+func (sv MCPServer) supervisorConfig() server.SupervisorConfig {
+	cfg := server.SupervisorConfig{
+		RetryLimit: sv.RetryLimit,
+		MaxProcs:   sv.MaxProcs,
+	}
+
+	if d, err := time.ParseDuration(sv.Backoff.Start); err == nil {
+		cfg.Backoff.Start = d
+	}
+	if d, err := time.ParseDuration(sv.Backoff.Cap); err == nil {
+		cfg.Backoff.Cap = d
+	}
+	if d, err := time.ParseDuration(sv.HealthcheckInterval); err == nil {
+		cfg.HealthcheckInterval = d
+	}
+
+	return cfg
 }
 
 type MCPAgent struct {
 	Name            string                 `mapstructure:"name"`
-	MCPAgentServers []agent.MCPAgentServer `mapstructure:"servers"`  // List of MCP servers used by this agent
-	Provider        string                 `mapstructure:"provider"` // Name of the LLM provider to use
+	MCPAgentServers []agent.MCPAgentServer `mapstructure:"servers"`     // List of MCP servers used by this agent
+	LocalTools      []string               `mapstructure:"local_tools"` // Names of built-in toolbox.Builtins this agent may use
+	Provider        string                 `mapstructure:"provider"`    // Name of the LLM provider to use
 }
 
 func Setup(ctx context.Context, providers []LLMProvider, servers []MCPServer, agents []MCPAgent) error {
@@ -55,11 +153,13 @@ func Setup(ctx context.Context, providers []LLMProvider, servers []MCPServer, ag
 	}
 	SysConfig.LLMModels = providersLLMs
 
-	serversMap, err := initMCPServers(ctx, servers)
+	SysConfig.Supervisors = map[string]*server.Supervisor{}
+	serversMap, toolCallers, err := initMCPServers(ctx, servers)
 	if err != nil {
 		return err
 	}
 	SysConfig.Servers = serversMap
+	SysConfig.ToolCallers = toolCallers
 
 	mapAgents, err := initAgents(ctx, agents)
 	if err != nil {
@@ -96,24 +196,45 @@ func initLLMProviders(providers []LLMProvider) (map[string]llms.Model, error) {
 	return providersLLMs, nil
 }
 
-func initMCPServers(ctx context.Context, servers []MCPServer) (map[string]server.MCPServer, error) {
+// initMCPServers connects every configured server and returns two views of
+// the result: serversMap holds a plain value snapshot of each server (for
+// display/inspection), while toolCallers holds the live object agents should
+// actually call through — the *server.Supervisor itself for a supervised
+// server, so its reconnects and round-robin stay reachable after agents are
+// built, rather than a value copy that's frozen at this point in time.
+func initMCPServers(ctx context.Context, servers []MCPServer) (map[string]server.MCPServer, map[string]server.ToolCaller, error) {
 	serversMap := make(map[string]server.MCPServer)
+	toolCallers := make(map[string]server.ToolCaller)
 
 	for _, sv := range servers {
-		mcpServer, err := server.NewMCPServer(ctx, sv.Name, sv.Type, sv.Command, sv.URL, sv.Headers)
+		if sv.supervised() {
+			supervisor, err := server.NewSupervisor(ctx, sv.Name, sv.Type, sv.Command, sv.URL, sv.Headers, sv.Auth.serverAuth(), sv.supervisorConfig())
+			if err != nil {
+				fmt.Printf("Error creating supervised MCP server %s: %+v\n", sv.Name, err)
+				return nil, nil, err
+			}
+			SysConfig.Supervisors[sv.Name] = supervisor
+			serversMap[sv.Name] = *supervisor.Current()
+			toolCallers[sv.Name] = supervisor
+			fmt.Printf("Supervised MCP server %q created successfully\n", sv.Name)
+			continue
+		}
+
+		mcpServer, err := server.NewMCPServer(ctx, sv.Name, sv.Type, sv.Command, sv.URL, sv.Headers, sv.Auth.serverAuth())
 		if err != nil {
 			fmt.Printf("Error creating MCP server %s: %+v\n", sv.Name, err)
-			return nil, err
+			return nil, nil, err
 		}
 		serversMap[sv.Name] = *mcpServer
+		toolCallers[sv.Name] = mcpServer
 		fmt.Printf("MCP server %q created successfully\n", sv.Name)
 	}
 
-	return serversMap, nil
+	return serversMap, toolCallers, nil
 }
 
-func initAgents(ctx context.Context, agents []MCPAgent) (map[string]agent.MCPAgent, error) {
-	mapAgents := make(map[string]agent.MCPAgent)
+func initAgents(ctx context.Context, agents []MCPAgent) (map[string]*agent.MCPAgent, error) {
+	mapAgents := make(map[string]*agent.MCPAgent)
 
 	for _, ag := range agents {
 		agentServers := slices.Map(ag.MCPAgentServers, func(srv agent.MCPAgentServer) agent.MCPAgentServer {
@@ -123,30 +244,37 @@ func initAgents(ctx context.Context, agents []MCPAgent) (map[string]agent.MCPAge
 			}
 		})
 
-		agentMCPServers := slices.Map(ag.MCPAgentServers, func(srv agent.MCPAgentServer) server.MCPServer {
-			mcpServer, ok := SysConfig.Servers[srv.Name]
+		agentToolCallers := map[string]server.ToolCaller{}
+		for _, srv := range ag.MCPAgentServers {
+			caller, ok := SysConfig.ToolCallers[srv.Name]
 			if !ok {
 				fmt.Printf("Error finding MCP server %s for agent %s\n", srv.Name, ag.Name)
-				return server.MCPServer{} // Return an empty server if not found
+				continue
 			}
-			return mcpServer
-		})
+			agentToolCallers[srv.Name] = caller
+		}
 
-		// Filter out any empty servers
-		agentMCPServers = slices.Filter(agentMCPServers, func(srv server.MCPServer) bool {
-			return srv.Name != ""
-		})
+		localTools := []api.ToolSpec{}
+		for _, toolName := range ag.LocalTools {
+			spec, ok := toolbox.Lookup(toolName)
+			if !ok {
+				fmt.Printf("Error finding local tool %s for agent %s\n", toolName, ag.Name)
+				continue
+			}
+			localTools = append(localTools, spec)
+		}
 
 		mcpAgent := agent.NewMCPAgent(
 			ctx,
 			ag.Name,
 			agentServers,
-			agentMCPServers,
+			agentToolCallers,
+			localTools,
 			ag.Provider,
 			SysConfig.LLMModels[ag.Provider],
 		)
 
-		mapAgents[ag.Name] = *mcpAgent
+		mapAgents[ag.Name] = mcpAgent
 		fmt.Printf("MCP Agent %q created successfully with servers: %q using provider %q\n", ag.Name, ag.MCPAgentServers, ag.Provider)
 	}
 
@@ -2,30 +2,59 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
+	"os"
 
+	"github.com/carlossantin/mcp-agents-go/agent"
 	"github.com/carlossantin/mcp-agents-go/config"
-	"github.com/tmc/langchaingo/llms"
 )
 
 func main() {
 	ctx := context.Background()
 
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		runServe(ctx, os.Args[2:])
+		return
+	}
+
 	config.SetupFromFile(ctx, "config.yaml")
 
 	ag, ok := config.SysConfig.Agents["my-agent"]
 	if ok {
-		msgs := []llms.MessageContent{
-			{Role: llms.ChatMessageTypeHuman, Parts: []llms.ContentPart{llms.TextContent{Text: "Give me the current dollar to real exchange rate in BRL."}}},
-		}
+		prompt := "Give me the current dollar to real exchange rate in BRL."
 
-		// resp, _ := ag.GenerateContent(ctx, msgs, true)
-		// fmt.Println(resp)
-		chanResp, _ := ag.GenerateContentAsStreaming(ctx, msgs, true)
-		for resp := range chanResp {
-			fmt.Print(resp)
+		chanResp := ag.GenerateContentAsStreaming(ctx, prompt, true)
+		finalText, _, err := agent.Collect(chanResp)
+		if err != nil {
+			panic(err)
 		}
+		fmt.Println(finalText)
 	} else {
 		panic("Agent my-agent not found in configuration")
 	}
 }
+
+// runServe implements `mcp-agents-go serve --agent <name> --transport stdio|sse|http`,
+// mounting a configured agent as its own MCP server so another MCP-aware
+// host can use it as a tool.
+func runServe(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	agentName := fs.String("agent", "", "Name of the agent to serve, as defined in config.yaml")
+	transportType := fs.String("transport", "stdio", "Transport to serve on: stdio, sse, or http")
+	configFile := fs.String("config", "config.yaml", "Path to the config file")
+	fs.Parse(args)
+
+	if err := config.SetupFromFile(ctx, *configFile); err != nil {
+		panic(err)
+	}
+
+	ag, ok := config.SysConfig.Agents[*agentName]
+	if !ok {
+		panic(fmt.Sprintf("Agent %q not found in configuration", *agentName))
+	}
+
+	if err := ag.Serve(ctx, *transportType); err != nil {
+		panic(err)
+	}
+}
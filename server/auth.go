@@ -0,0 +1,180 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuthType selects how an MCPServer authenticates with a remote endpoint.
+type AuthType string
+
+const (
+	AuthNone                    AuthType = ""
+	AuthBearer                  AuthType = "bearer"
+	AuthOAuth2ClientCredentials AuthType = "oauth2_client_credentials"
+)
+
+// AuthConfig configures authentication for the sse and streamable-http
+// transports.
+type AuthConfig struct {
+	Type AuthType
+
+	// BearerToken is sent as-is when Type is AuthBearer.
+	BearerToken string
+
+	// StaticHeaders are attached to every request regardless of Type.
+	StaticHeaders map[string]string
+
+	// TokenURL, ClientID, ClientSecret, and Scopes configure the
+	// client-credentials grant used when Type is AuthOAuth2ClientCredentials.
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	// Leeway refreshes the cached token this long before it actually
+	// expires, so a request never races a token that just went stale.
+	// Defaults to 30s.
+	Leeway time.Duration
+}
+
+type oauthToken struct {
+	AccessToken string
+	ExpiresAt   time.Time
+}
+
+var (
+	tokenCacheMu sync.Mutex
+	tokenCache   = map[string]*oauthToken{}
+)
+
+// resolveHeaders returns the headers createMCPClient should attach for this
+// server, fetching an OAuth2 token (and starting its background refresh
+// loop) the first time it's needed.
+func (m *MCPServer) resolveHeaders(ctx context.Context) (map[string]string, error) {
+	headers := map[string]string{}
+	for _, header := range m.Headers {
+		key, value, ok := strings.Cut(header, ":")
+		if !ok {
+			continue
+		}
+		headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	for k, v := range m.Auth.StaticHeaders {
+		headers[k] = v
+	}
+
+	switch m.Auth.Type {
+	case AuthBearer:
+		headers["Authorization"] = "Bearer " + m.Auth.BearerToken
+	case AuthOAuth2ClientCredentials:
+		token, err := m.oauthAccessToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+		headers["Authorization"] = "Bearer " + token
+	}
+
+	return headers, nil
+}
+
+func (m *MCPServer) authLeeway() time.Duration {
+	if m.Auth.Leeway > 0 {
+		return m.Auth.Leeway
+	}
+	return 30 * time.Second
+}
+
+// oauthAccessToken returns a cached token for this server, fetching a new
+// one if missing or within authLeeway of expiring, then keeps the cache warm
+// by scheduling its own refresh in the background.
+func (m *MCPServer) oauthAccessToken(ctx context.Context) (string, error) {
+	tokenCacheMu.Lock()
+	cached, ok := tokenCache[m.Name]
+	tokenCacheMu.Unlock()
+
+	if ok && time.Now().Add(m.authLeeway()).Before(cached.ExpiresAt) {
+		return cached.AccessToken, nil
+	}
+
+	token, err := m.fetchOAuthToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	tokenCacheMu.Lock()
+	tokenCache[m.Name] = token
+	tokenCacheMu.Unlock()
+
+	go m.refreshOAuthTokenLoop(token)
+
+	return token.AccessToken, nil
+}
+
+// refreshOAuthTokenLoop re-fetches the token authLeeway before it expires,
+// keeping the cache warm without making a request wait on the refresh.
+func (m *MCPServer) refreshOAuthTokenLoop(current *oauthToken) {
+	wait := time.Until(current.ExpiresAt) - m.authLeeway()
+	if wait < 0 {
+		wait = 0
+	}
+	time.Sleep(wait)
+
+	token, err := m.fetchOAuthToken(context.Background())
+	if err != nil {
+		fmt.Printf("Failed to refresh OAuth2 token for MCP server %s: %v\n", m.Name, err)
+		return
+	}
+
+	tokenCacheMu.Lock()
+	tokenCache[m.Name] = token
+	tokenCacheMu.Unlock()
+
+	go m.refreshOAuthTokenLoop(token)
+}
+
+// fetchOAuthToken performs the client-credentials grant against
+// m.Auth.TokenURL.
+func (m *MCPServer) fetchOAuthToken(ctx context.Context) (*oauthToken, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", m.Auth.ClientID)
+	form.Set("client_secret", m.Auth.ClientSecret)
+	if len(m.Auth.Scopes) > 0 {
+		form.Set("scope", strings.Join(m.Auth.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.Auth.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build oauth2 token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch oauth2 token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oauth2 token endpoint for %s returned status %d", m.Name, resp.StatusCode)
+	}
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode oauth2 token response: %w", err)
+	}
+
+	return &oauthToken{
+		AccessToken: body.AccessToken,
+		ExpiresAt:   time.Now().Add(time.Duration(body.ExpiresIn) * time.Second),
+	}, nil
+}
@@ -3,7 +3,6 @@ package server
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/mark3labs/mcp-go/client"
@@ -14,23 +13,49 @@ import (
 
 type MCPServer struct {
 	Name    string              `json:"name"`
-	Type    string              `json:"type"`              // e.g., local, sse, stdio
+	Type    string              `json:"type"`              // e.g., local, sse, stdio, streamable-http
 	Command []string            `json:"command,omitempty"` // Command to start the server (for stdio type)
-	URL     string              `json:"url,omitempty"`     // URL for the server connection (for sse type)
-	Headers []string            `json:"headers,omitempty"` // Headers for the server connection (for sse type)
+	URL     string              `json:"url,omitempty"`     // URL for the server connection (for sse/streamable-http type)
+	Headers []string            `json:"headers,omitempty"` // Headers for the server connection (for sse/streamable-http type)
+	Auth    AuthConfig          `json:"-"`                 // Authentication for the sse/streamable-http type
 	Client  mcpclient.MCPClient `json:"client"`            // The MCP client used to communicate with this server
 }
 
+// ToolCaller is the subset of behavior an agent needs from an MCP server
+// connection: call a tool, and list what's available. *MCPServer satisfies
+// it by delegating straight to its Client; *Supervisor satisfies it by
+// round-robining across its live procs and reconnecting on failure. Code
+// that holds a ToolCaller instead of a concrete *MCPServer keeps working
+// transparently if that server is later supervised.
+type ToolCaller interface {
+	CallTool(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error)
+	ListTools(ctx context.Context, req mcp.ListToolsRequest) (*mcp.ListToolsResult, error)
+}
+
+// CallTool delegates to s.Client, so a plain (non-supervised) MCPServer
+// satisfies ToolCaller the same way a Supervisor does.
+func (s *MCPServer) CallTool(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return s.Client.CallTool(ctx, req)
+}
+
+// ListTools delegates to s.Client, so a plain (non-supervised) MCPServer
+// satisfies ToolCaller the same way a Supervisor does.
+func (s *MCPServer) ListTools(ctx context.Context, req mcp.ListToolsRequest) (*mcp.ListToolsResult, error) {
+	return s.Client.ListTools(ctx, req)
+}
+
 // NewMCPServer creates a new MCPServer instance and initializes the MCP client.
-// It supports different server types: stdio, sse
-// The command is used for stdio type servers, while URL and headers are used for sse type servers.
-func NewMCPServer(ctx context.Context, name, serverType string, command []string, url string, headers []string) (*MCPServer, error) {
+// It supports different server types: stdio, sse, streamable-http.
+// The command is used for stdio type servers, while URL, headers, and auth
+// are used for the sse and streamable-http types.
+func NewMCPServer(ctx context.Context, name, serverType string, command []string, url string, headers []string, auth AuthConfig) (*MCPServer, error) {
 	s := &MCPServer{
 		Name:    name,
 		Type:    serverType,
 		Command: command,
 		URL:     url,
 		Headers: headers,
+		Auth:    auth,
 	}
 
 	cli, err := s.createMCPClient(ctx)
@@ -63,6 +88,8 @@ func (s *MCPServer) GetTransportType() string {
 		return "stdio"
 	case "sse":
 		return "sse"
+	case "http", "streamable-http":
+		return "streamable-http"
 	default:
 		return "stdio"
 	}
@@ -100,22 +127,14 @@ func (m *MCPServer) createMCPClient(ctx context.Context) (mcpclient.MCPClient, e
 		return stdioClient, nil
 	case "sse":
 		// SSE client
-		var options []transport.ClientOption
+		headers, err := m.resolveHeaders(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve headers: %w", err)
+		}
 
-		// Add headers if specified
-		if len(m.Headers) > 0 {
-			headers := make(map[string]string)
-			for _, header := range m.Headers {
-				parts := strings.SplitN(header, ":", 2)
-				if len(parts) == 2 {
-					key := strings.TrimSpace(parts[0])
-					value := strings.TrimSpace(parts[1])
-					headers[key] = value
-				}
-			}
-			if len(headers) > 0 {
-				options = append(options, transport.WithHeaders(headers))
-			}
+		var options []transport.ClientOption
+		if len(headers) > 0 {
+			options = append(options, transport.WithHeaders(headers))
 		}
 
 		sseClient, err := client.NewSSEMCPClient(m.URL, options...)
@@ -129,6 +148,29 @@ func (m *MCPServer) createMCPClient(ctx context.Context) (mcpclient.MCPClient, e
 		}
 
 		return sseClient, nil
+	case "streamable-http":
+		// Streamable HTTP client: a single POST endpoint with an optional
+		// SSE response, the transport the MCP spec has moved to.
+		headers, err := m.resolveHeaders(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve headers: %w", err)
+		}
+
+		var options []transport.StreamableHTTPCOption
+		if len(headers) > 0 {
+			options = append(options, transport.WithHTTPHeaders(headers))
+		}
+
+		httpClient, err := client.NewStreamableHttpClient(m.URL, options...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create streamable-http client: %v", err)
+		}
+
+		if err := httpClient.Start(ctx); err != nil {
+			return nil, fmt.Errorf("failed to start streamable-http client: %v", err)
+		}
+
+		return httpClient, nil
 
 	default:
 		return nil, fmt.Errorf("unsupported transport type '%s' for server %s", transportType, m.Name)
@@ -0,0 +1,216 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// BackoffConfig controls the exponential backoff a Supervisor applies
+// between reconnect attempts.
+type BackoffConfig struct {
+	Start time.Duration // Delay before the first retry.
+	Cap   time.Duration // Maximum delay between retries.
+}
+
+// SupervisorConfig configures a Supervisor's connection lifecycle.
+type SupervisorConfig struct {
+	RetryLimit          int           // Max reconnect attempts before giving up. Default math.MaxInt32.
+	Backoff             BackoffConfig // Default 1s start, 30s cap.
+	MaxProcs            int           // Live stdio subprocesses to keep running, round-robined. Default 1.
+	HealthcheckInterval time.Duration // How often to probe liveness. Default 30s.
+}
+
+// DefaultSupervisorConfig returns the knob defaults described in
+// SupervisorConfig's field comments.
+func DefaultSupervisorConfig() SupervisorConfig {
+	return SupervisorConfig{
+		RetryLimit:          math.MaxInt32,
+		Backoff:             BackoffConfig{Start: time.Second, Cap: 30 * time.Second},
+		MaxProcs:            1,
+		HealthcheckInterval: 30 * time.Second,
+	}
+}
+
+// Both connection types satisfy ToolCaller, so callers (agent.MCPAgent in
+// particular) can hold either behind the same interface without caring
+// whether a given server is supervised.
+var (
+	_ ToolCaller = (*MCPServer)(nil)
+	_ ToolCaller = (*Supervisor)(nil)
+)
+
+// Supervisor wraps one or more MCPServer connections to the same logical
+// server, reconnecting with exponential backoff on failure and probing
+// liveness on an interval instead of letting a dead stdio subprocess or
+// dropped SSE connection kill the agent forever.
+type Supervisor struct {
+	Name    string
+	Type    string
+	Command []string
+	URL     string
+	Headers []string
+	Auth    AuthConfig
+	config  SupervisorConfig
+
+	mu      sync.Mutex
+	procs   []*MCPServer
+	rrIndex uint64
+
+	cancel context.CancelFunc
+}
+
+// NewSupervisor connects to name using the same parameters as NewMCPServer,
+// then keeps the connection (or, for stdio servers, config.MaxProcs
+// connections) alive for the life of the process.
+func NewSupervisor(ctx context.Context, name, serverType string, command []string, url string, headers []string, auth AuthConfig, config SupervisorConfig) (*Supervisor, error) {
+	if config.RetryLimit <= 0 {
+		config.RetryLimit = math.MaxInt32
+	}
+	if config.Backoff.Start <= 0 {
+		config.Backoff.Start = time.Second
+	}
+	if config.Backoff.Cap <= 0 {
+		config.Backoff.Cap = 30 * time.Second
+	}
+	if config.MaxProcs <= 0 {
+		config.MaxProcs = 1
+	}
+	if config.HealthcheckInterval <= 0 {
+		config.HealthcheckInterval = 30 * time.Second
+	}
+
+	s := &Supervisor{
+		Name:    name,
+		Type:    serverType,
+		Command: command,
+		URL:     url,
+		Headers: headers,
+		Auth:    auth,
+		config:  config,
+	}
+
+	procCount := config.MaxProcs
+	if (&MCPServer{Type: serverType}).GetTransportType() != "stdio" {
+		// Only stdio servers round-robin across independent subprocesses;
+		// a single SSE/HTTP connection is shared instead.
+		procCount = 1
+	}
+
+	s.procs = make([]*MCPServer, procCount)
+	for i := 0; i < procCount; i++ {
+		srv, err := s.connectWithRetry(ctx)
+		if err != nil {
+			return nil, err
+		}
+		s.procs[i] = srv
+	}
+
+	healthCtx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	go s.healthcheckLoop(healthCtx)
+
+	return s, nil
+}
+
+// connectWithRetry calls NewMCPServer, making up to config.RetryLimit
+// attempts in total with exponential backoff (plus jitter) between them.
+func (s *Supervisor) connectWithRetry(ctx context.Context) (*MCPServer, error) {
+	backoff := s.config.Backoff.Start
+	var lastErr error
+
+	for attempt := 1; attempt <= s.config.RetryLimit; attempt++ {
+		srv, err := NewMCPServer(ctx, s.Name, s.Type, s.Command, s.URL, s.Headers, s.Auth)
+		if err == nil {
+			return srv, nil
+		}
+		lastErr = err
+		fmt.Printf("Supervisor: failed to connect to MCP server %s (attempt %d/%d): %v\n", s.Name, attempt, s.config.RetryLimit, err)
+
+		jitter := time.Duration(rand.Int63n(int64(backoff) + 1))
+		time.Sleep(backoff/2 + jitter/2)
+
+		backoff *= 2
+		if backoff > s.config.Backoff.Cap {
+			backoff = s.config.Backoff.Cap
+		}
+	}
+
+	return nil, fmt.Errorf("failed to connect to MCP server %s after %d attempts: %w", s.Name, s.config.RetryLimit, lastErr)
+}
+
+// healthcheckLoop probes each proc on config.HealthcheckInterval and
+// reconnects it (re-running Initialize via NewMCPServer) when the probe
+// fails. The tool list is not re-fetched on reconnect: agents keep the tool
+// list they fetched when they were built.
+func (s *Supervisor) healthcheckLoop(ctx context.Context) {
+	ticker := time.NewTicker(s.config.HealthcheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			for i, proc := range s.procs {
+				if _, err := proc.Client.ListTools(ctx, mcp.ListToolsRequest{}); err == nil {
+					continue
+				}
+
+				fmt.Printf("Supervisor: healthcheck failed for MCP server %s (proc %d), reconnecting\n", s.Name, i)
+				newProc, err := s.connectWithRetry(ctx)
+				if err != nil {
+					fmt.Printf("Supervisor: failed to reconnect MCP server %s (proc %d): %v\n", s.Name, i, err)
+					continue
+				}
+				s.procs[i] = newProc
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+// next returns the next proc to use, round-robining across s.procs.
+func (s *Supervisor) next() *MCPServer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	idx := atomic.AddUint64(&s.rrIndex, 1)
+	return s.procs[idx%uint64(len(s.procs))]
+}
+
+// CallTool dispatches to the next live proc in round-robin order.
+func (s *Supervisor) CallTool(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return s.next().Client.CallTool(ctx, req)
+}
+
+// ListTools dispatches to the next live proc in round-robin order.
+func (s *Supervisor) ListTools(ctx context.Context, req mcp.ListToolsRequest) (*mcp.ListToolsResult, error) {
+	return s.next().Client.ListTools(ctx, req)
+}
+
+// Current returns the first proc (procs[0]), regardless of where the
+// round-robin rotation stands, for diagnostics or callers that need a plain
+// *MCPServer value (e.g. to print its Name/Type/URL). The Supervisor itself
+// keeps rotating and reconnecting procs behind the scenes, so callers that
+// need calls to keep working across a reconnect should hold the Supervisor
+// as a ToolCaller instead of caching what Current returns.
+func (s *Supervisor) Current() *MCPServer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.procs[0]
+}
+
+// Stop ends the healthcheck loop. It does not close the underlying
+// connections, matching NewMCPServer/MCPServer, which expose no Close today.
+func (s *Supervisor) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
@@ -0,0 +1,53 @@
+package server
+
+import "testing"
+
+// newTestSupervisor builds a Supervisor around bare procs (no live Client),
+// so next()'s round-robin rotation can be exercised without connecting to a
+// real MCP server.
+func newTestSupervisor(names ...string) *Supervisor {
+	procs := make([]*MCPServer, len(names))
+	for i, name := range names {
+		procs[i] = &MCPServer{Name: name}
+	}
+	return &Supervisor{procs: procs}
+}
+
+func TestSupervisorNextRoundRobin(t *testing.T) {
+	s := newTestSupervisor("a", "b", "c")
+
+	var got []string
+	for i := 0; i < 7; i++ {
+		got = append(got, s.next().Name)
+	}
+
+	want := []string{"b", "c", "a", "b", "c", "a", "b"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("next() sequence = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestSupervisorNextSingleProc(t *testing.T) {
+	s := newTestSupervisor("only")
+
+	for i := 0; i < 3; i++ {
+		if got := s.next().Name; got != "only" {
+			t.Fatalf("next() = %q, want %q", got, "only")
+		}
+	}
+}
+
+func TestSupervisorCurrent(t *testing.T) {
+	s := newTestSupervisor("a", "b")
+
+	if got := s.Current().Name; got != "a" {
+		t.Fatalf("Current() = %q, want %q", got, "a")
+	}
+
+	s.next()
+	if got := s.Current().Name; got != "a" {
+		t.Fatalf("Current() after next() = %q, want %q (Current is always procs[0])", got, "a")
+	}
+}